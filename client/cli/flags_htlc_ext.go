@@ -0,0 +1,21 @@
+package cli
+
+const (
+	// FlagDestChain is the channel id used to route a create-HTLC
+	// transaction through IBC, opening a mirrored HTLC on the chain at the
+	// other end of the channel in addition to locking funds locally
+	FlagDestChain = "dest-chain"
+
+	// FlagHashAlgo selects the hash lock algorithm a create-HTLC
+	// transaction uses to derive its hash lock from the secret
+	FlagHashAlgo = "hash-algo"
+
+	// FlagState filters the htlcs query by HTLC state
+	FlagState = "state"
+
+	// FlagSender filters the htlcs query by sender address
+	FlagSender = "sender"
+
+	// FlagReceiver filters the htlcs query by receiver address
+	FlagReceiver = "receiver"
+)
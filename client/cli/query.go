@@ -0,0 +1,192 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"github.com/irismod/htlc/types"
+)
+
+// GetQueryCmd returns the query commands for this module
+func GetQueryCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
+	htlcQueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the htlc module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	htlcQueryCmd.AddCommand(flags.GetCommands(
+		GetCmdQueryHTLC(storeKey, cdc),
+		GetCmdQueryHTLCs(storeKey, cdc),
+		GetCmdQueryParams(storeKey, cdc),
+	)...)
+
+	return htlcQueryCmd
+}
+
+// GetCmdQueryHTLC implements looking up a single HTLC by its hash lock
+func GetCmdQueryHTLC(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "htlc [hash-lock]",
+		Short: "Query an HTLC by hash lock",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query the details of a single HTLC.
+
+Example:
+$ %s query htlc htlc <hash-lock>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			hashLock, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			bz, err := cliCtx.Codec.MarshalJSON(types.NewQueryHTLCParams(hashLock))
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", storeKey, types.QueryHTLC), bz)
+			if err != nil {
+				return err
+			}
+
+			var htlc types.HTLC
+			if err := cdc.UnmarshalJSON(res, &htlc); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(htlc)
+		},
+	}
+}
+
+// GetCmdQueryHTLCs implements the filtered, paginated HTLC list query
+func GetCmdQueryHTLCs(storeKey string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "htlcs",
+		Short: "Query all HTLCs",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Query HTLCs with optional state, sender and receiver filters.
+
+Example:
+$ %s query htlc htlcs --state=open --sender=<sender-address>
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			var (
+				sender, receiver sdk.AccAddress
+				err              error
+			)
+
+			if s := viper.GetString(FlagSender); len(s) > 0 {
+				if sender, err = sdk.AccAddressFromBech32(s); err != nil {
+					return err
+				}
+			}
+
+			if r := viper.GetString(FlagReceiver); len(r) > 0 {
+				if receiver, err = sdk.AccAddressFromBech32(r); err != nil {
+					return err
+				}
+			}
+
+			var (
+				state    types.HTLCState
+				hasState bool
+			)
+
+			if s := viper.GetString(FlagState); len(s) > 0 {
+				hasState = true
+				switch strings.ToLower(s) {
+				case "open":
+					state = types.Open
+				case "completed":
+					state = types.Completed
+				case "refunded":
+					state = types.Refunded
+				default:
+					return fmt.Errorf("invalid state %s, must be one of open, completed, refunded", s)
+				}
+			}
+
+			params := types.NewQueryHTLCsParams(
+				state, hasState, sender, receiver,
+				viper.GetInt(flags.FlagPage), viper.GetInt(flags.FlagLimit),
+			)
+
+			bz, err := cliCtx.Codec.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", storeKey, types.QueryHTLCs), bz)
+			if err != nil {
+				return err
+			}
+
+			var htlcs []types.HTLC
+			if err := cdc.UnmarshalJSON(res, &htlcs); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(htlcs)
+		},
+	}
+
+	cmd.Flags().String(FlagState, "", "filter by HTLC state: open, completed, refunded")
+	cmd.Flags().String(FlagSender, "", "filter by sender address")
+	cmd.Flags().String(FlagReceiver, "", "filter by receiver address")
+	cmd.Flags().Int(flags.FlagPage, 1, "pagination page of HTLCs to query for")
+	cmd.Flags().Int(flags.FlagLimit, 100, "pagination limit of HTLCs to query for")
+
+	return cmd
+}
+
+// GetCmdQueryParams implements the params query command
+func GetCmdQueryParams(storeKey string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Query the current htlc module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", storeKey, types.QueryParams), nil)
+			if err != nil {
+				return err
+			}
+
+			var params types.Params
+			if err := cdc.UnmarshalJSON(res, &params); err != nil {
+				return err
+			}
+
+			return cliCtx.PrintOutput(params)
+		},
+	}
+}
@@ -36,6 +36,8 @@ func GetTxCmd(storeKey string, cdc *codec.Codec) *cobra.Command {
 		GetCmdCreateHTLC(cdc),
 		GetCmdClaimHTLC(cdc),
 		GetCmdRefundHTLC(cdc),
+		GetCmdCreateRoutedHTLC(cdc),
+		GetCmdForwardHTLC(cdc),
 	)...)
 
 	return htlcTxCmd
@@ -50,10 +52,13 @@ func GetCmdCreateHTLC(cdc *codec.Codec) *cobra.Command {
 			fmt.Sprintf(`Create an HTLC.
 
 Example:
-$ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<receiver-on-other-chain> --amount=<amount> 
+$ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<receiver-on-other-chain> --amount=<amount>
 --secret=<secret> --hash-lock=<hash-lock> --timestamp=<timestamp> --time-lock=<time-lock> --from=mykey
+
+Example (cross-chain swap over IBC):
+$ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<counterparty-address> --dest-chain=<channel-id> --amount=<amount> --time-lock=<time-lock> --from=mykey
 `,
-				version.ClientName,
+				version.ClientName, version.ClientName,
 			),
 		),
 		PreRunE: preCheckCmd,
@@ -79,6 +84,11 @@ $ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<receiver-on-othe
 			timestamp := viper.GetInt64(FlagTimestamp)
 			timeLock := viper.GetInt64(FlagTimeLock)
 
+			hashAlgo, err := types.HashAlgoFromString(viper.GetString(FlagHashAlgo))
+			if err != nil {
+				return err
+			}
+
 			secret := make([]byte, 32)
 			var hashLock []byte
 
@@ -104,17 +114,24 @@ $ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<receiver-on-othe
 					}
 				}
 
-				hashLock = types.GetHashLock(secret, uint64(timestamp))
+				hashLock = types.GetHashLockByAlgo(secret, uint64(timestamp), hashAlgo)
 			}
 
 			msg := types.NewMsgCreateHTLC(
 				sender, to, receiverOnOtherChain, amount,
 				hashLock, uint64(timestamp), uint64(timeLock),
 			)
+			msg.HashAlgo = hashAlgo
 			if err := msg.ValidateBasic(); err != nil {
 				return err
 			}
 
+			if destChain := viper.GetString(FlagDestChain); len(destChain) > 0 {
+				// destChain names the source channel on this chain; the
+				// mirrored HTLC is opened on whatever chain it connects to.
+				msg.DestChain = destChain
+			}
+
 			if err = authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}); err == nil && !flags.Changed(FlagHashLock) {
 				fmt.Println("**Important** save this secret, hashLock in a safe place.")
 				fmt.Println("It is the only way to claim or refund the locked coins from an HTLC")
@@ -129,6 +146,8 @@ $ %s tx htlc create --to=<recipient> --receiver-on-other-chain=<receiver-on-othe
 	}
 
 	cmd.Flags().AddFlagSet(FsCreateHTLC)
+	cmd.Flags().String(FlagDestChain, "", "channel id to route the swap through IBC, opening a mirrored HTLC on the connected chain")
+	cmd.Flags().String(FlagHashAlgo, "sha256", "hash lock algorithm to use: sha256, sha256d, ripemd160sha256, keccak256")
 	_ = cmd.MarkFlagRequired(FlagTo)
 	_ = cmd.MarkFlagRequired(FlagAmount)
 	_ = cmd.MarkFlagRequired(FlagTimeLock)
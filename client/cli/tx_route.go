@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authclient "github.com/cosmos/cosmos-sdk/x/auth/client"
+
+	"github.com/irismod/htlc/types"
+)
+
+// GetCmdCreateRoutedHTLC implements opening a multi-hop routed HTLC
+func GetCmdCreateRoutedHTLC(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "route [route-file]",
+		Short: "Open a multi-hop routed HTLC",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Open a multi-hop HTLC across a route of forwarders, sharing a single hash lock.
+
+route-file is a JSON array of hops: [{"forwarder":"<addr>","amount":"<coins>","fee":"<coins>","time_lock_delta":<n>}, ...]
+
+Example:
+$ %s tx htlc route route.json --time-lock=<time-lock> --from=mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			sender := cliCtx.GetFromAddress()
+
+			routeBz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var route []types.Hop
+			if err := cdc.UnmarshalJSON(routeBz, &route); err != nil {
+				return err
+			}
+
+			hashAlgo, err := types.HashAlgoFromString(viper.GetString(FlagHashAlgo))
+			if err != nil {
+				return err
+			}
+
+			timestamp := viper.GetInt64(FlagTimestamp)
+			timeLock := viper.GetInt64(FlagTimeLock)
+
+			secret := make([]byte, 32)
+			if _, err := rand.Read(secret); err != nil {
+				return err
+			}
+			hashLock := types.GetHashLockByAlgo(secret, uint64(timestamp), hashAlgo)
+
+			msg := types.NewMsgCreateRoutedHTLC(sender, route, hashLock, hashAlgo, uint64(timestamp), uint64(timeLock))
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			if err = authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg}); err == nil {
+				fmt.Println("**Important** save this secret, hashLock in a safe place.")
+				fmt.Println("It is the only way to claim or refund the locked coins from this routed HTLC")
+				fmt.Println()
+				fmt.Printf("Secret:      %s\nHashLock:    %s\n",
+					hex.EncodeToString(secret), hex.EncodeToString(hashLock),
+				)
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().String(FlagHashAlgo, "sha256", "hash lock algorithm to use: sha256, sha256d, ripemd160sha256, keccak256")
+	cmd.Flags().Int64(FlagTimestamp, 0, "timestamp mixed into the hash lock, in seconds")
+	cmd.Flags().Int64(FlagTimeLock, 0, "time lock, in blocks, for the first hop of the route")
+	_ = cmd.MarkFlagRequired(FlagTimeLock)
+
+	return cmd
+}
+
+// GetCmdForwardHTLC implements forwarding a routed HTLC to the next hop
+func GetCmdForwardHTLC(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "forward [hash-lock] [hop-index]",
+		Short: "Open the next hop of a routed HTLC",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Forward a routed HTLC you are currently holding to the next hop in its route.
+
+Example:
+$ %s tx htlc forward <hash-lock> 0 --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(auth.DefaultTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			forwarder := cliCtx.GetFromAddress()
+
+			hashLock, err := hex.DecodeString(args[0])
+			if err != nil {
+				return err
+			}
+
+			var hopIndex uint32
+			if _, err := fmt.Sscanf(args[1], "%d", &hopIndex); err != nil {
+				return fmt.Errorf("invalid hop index: %s", args[1])
+			}
+
+			msg := types.NewMsgForwardHTLC(forwarder, hashLock, hopIndex)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return authclient.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
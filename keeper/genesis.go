@@ -0,0 +1,111 @@
+package keeper
+
+import (
+	"encoding/hex"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/irismod/htlc/types"
+)
+
+// InitGenesis initializes the htlc module's state from a provided genesis
+// state, restoring every pending, completed and refunded HTLC along with
+// their secondary indexes and outstanding IBC links
+func InitGenesis(ctx sdk.Context, k Keeper, data types.GenesisState) {
+	k.SetParams(ctx, data.Params)
+
+	for _, htlc := range data.PendingHTLCs {
+		if err := k.SetHTLC(ctx, htlc); err != nil {
+			panic(err)
+		}
+		k.setIndexes(ctx, htlc)
+	}
+
+	for _, htlc := range data.CompletedHTLCs {
+		if err := k.SetHTLC(ctx, htlc); err != nil {
+			panic(err)
+		}
+		k.setIndexes(ctx, htlc)
+	}
+
+	for _, htlc := range data.RefundedHTLCs {
+		if err := k.SetHTLC(ctx, htlc); err != nil {
+			panic(err)
+		}
+		k.setIndexes(ctx, htlc)
+	}
+
+	for _, link := range data.IBCHTLCs {
+		k.SetIBCHTLC(ctx, link)
+	}
+
+	for _, routed := range data.RoutedHTLCs {
+		k.SetRoute(ctx, routed)
+	}
+
+	for _, hopHTLC := range data.RoutedHopHTLCs {
+		k.SetHopHTLC(ctx, hopHTLC.HashLock, hopHTLC.HopIndex, hopHTLC.HTLC)
+	}
+}
+
+// ExportGenesis returns the htlc module's state as a GenesisState, reading
+// every HTLC from the primary store and splitting them by their State field
+// into pending, completed and refunded buckets so the full history survives
+// a chain upgrade
+func ExportGenesis(ctx sdk.Context, k Keeper) types.GenesisState {
+	pending := make(map[string]types.HTLC)
+	var completed, refunded []types.HTLC
+
+	for _, hashLock := range k.hashLocksByPrefix(ctx, []byte{prefixHTLC}) {
+		htlc, ok := k.GetHTLC(ctx, hashLock)
+		if !ok {
+			continue
+		}
+
+		switch htlc.State {
+		case types.Open:
+			pending[hex.EncodeToString(htlc.HashLock)] = htlc
+		case types.Completed:
+			completed = append(completed, htlc)
+		case types.Refunded:
+			refunded = append(refunded, htlc)
+		}
+	}
+
+	var ibcHTLCs []types.IBCHTLC
+	for _, hashLock := range k.hashLocksByPrefix(ctx, []byte{prefixIBCHTLC}) {
+		link, ok := k.GetIBCHTLC(ctx, hashLock)
+		if !ok {
+			continue
+		}
+		ibcHTLCs = append(ibcHTLCs, link)
+	}
+
+	var routedHTLCs []types.RoutedHTLC
+	var routedHopHTLCs []types.RoutedHopHTLC
+	for _, hashLock := range k.hashLocksByPrefix(ctx, []byte{prefixRoute}) {
+		route, ok := k.GetRoute(ctx, hashLock)
+		if !ok {
+			continue
+		}
+		routedHTLCs = append(routedHTLCs, route)
+
+		for hopIndex := range route.Route {
+			hopHTLC, ok := k.GetHopHTLC(ctx, hashLock, uint32(hopIndex))
+			if !ok {
+				continue
+			}
+			routedHopHTLCs = append(routedHopHTLCs, types.NewRoutedHopHTLC(hashLock, uint32(hopIndex), hopHTLC))
+		}
+	}
+
+	return types.GenesisState{
+		PendingHTLCs:   pending,
+		CompletedHTLCs: completed,
+		RefundedHTLCs:  refunded,
+		IBCHTLCs:       ibcHTLCs,
+		RoutedHTLCs:    routedHTLCs,
+		RoutedHopHTLCs: routedHopHTLCs,
+		Params:         k.GetParams(ctx),
+	}
+}
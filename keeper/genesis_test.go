@@ -0,0 +1,50 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irismod/htlc/keeper"
+	"github.com/irismod/htlc/types"
+)
+
+func TestInitExportGenesisRoundTrip(t *testing.T) {
+	k, ctx, _ := setupTestKeeper(t)
+
+	sender := sdk.AccAddress("sender_____________")
+	receiver := sdk.AccAddress("receiver___________")
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 50))
+	openLock := make([]byte, types.HashLockLength)
+	openLock[0] = 0x01
+
+	completedLock := make([]byte, types.HashLockLength)
+	completedLock[0] = 0x02
+
+	openHTLC := types.NewHTLC(sender, receiver, "", amount, openLock, 0, 100, types.Open)
+	completedHTLC := types.NewHTLC(sender, receiver, "", amount, completedLock, 0, 100, types.Completed)
+
+	genesis := types.GenesisState{
+		PendingHTLCs:   map[string]types.HTLC{"01": openHTLC},
+		CompletedHTLCs: []types.HTLC{completedHTLC},
+		RefundedHTLCs:  []types.HTLC{},
+		IBCHTLCs:       []types.IBCHTLC{},
+		RoutedHTLCs:    []types.RoutedHTLC{},
+		RoutedHopHTLCs: []types.RoutedHopHTLC{},
+		Params:         types.DefaultParams(),
+	}
+
+	keeper.InitGenesis(ctx, k, genesis)
+
+	// the query-filtering index written by InitGenesis must make the
+	// imported HTLCs visible by sender, the same as HTLCs created through a
+	// normal transaction
+	bySender := k.FilterHTLCs(ctx, types.NewQueryHTLCsParams(types.Open, false, sender, nil, 1, 100))
+	require.Len(t, bySender, 2)
+
+	exported := keeper.ExportGenesis(ctx, k)
+	require.Len(t, exported.PendingHTLCs, 1)
+	require.Len(t, exported.CompletedHTLCs, 1)
+	require.Len(t, exported.RefundedHTLCs, 0)
+}
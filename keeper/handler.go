@@ -0,0 +1,88 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/htlc/types"
+)
+
+// NewHandler returns a handler for all htlc module messages
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (*sdk.Result, error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		switch msg := msg.(type) {
+		case types.MsgCreateHTLC:
+			return handleMsgCreateHTLC(ctx, k, msg)
+		case types.MsgClaimHTLC:
+			return handleMsgClaimHTLC(ctx, k, msg)
+		case types.MsgRefundHTLC:
+			return handleMsgRefundHTLC(ctx, k, msg)
+		case types.MsgCreateRoutedHTLC:
+			return handleMsgCreateRoutedHTLC(ctx, k, msg)
+		case types.MsgForwardHTLC:
+			return handleMsgForwardHTLC(ctx, k, msg)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", types.ModuleName, msg)
+		}
+	}
+}
+
+// handleMsgCreateHTLC locks the local leg of the swap, then, if the message
+// names a destination channel, opens the mirrored leg over IBC
+func handleMsgCreateHTLC(ctx sdk.Context, k Keeper, msg types.MsgCreateHTLC) (*sdk.Result, error) {
+	// msg.TimeLock is a relative number of blocks; ExpireHeight, like every
+	// other height stored on chain, must be absolute so RefundHTLC can gate
+	// on ctx.BlockHeight() without re-deriving the creation height
+	expireHeight := uint64(ctx.BlockHeight()) + msg.TimeLock
+
+	htlc := types.NewHTLCWithAlgo(
+		msg.Sender, msg.To, msg.ReceiverOnOtherChain, msg.Amount,
+		msg.HashLock, msg.HashAlgo, msg.Timestamp, expireHeight, types.Open,
+	)
+
+	if err := k.CreateHTLC(ctx, htlc); err != nil {
+		return nil, err
+	}
+
+	if len(msg.DestChain) > 0 {
+		if err := k.SendCrossChainHTLC(ctx, msg.DestChain, htlc, msg.TimeLock); err != nil {
+			return nil, err
+		}
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgClaimHTLC(ctx sdk.Context, k Keeper, msg types.MsgClaimHTLC) (*sdk.Result, error) {
+	if err := k.ClaimHTLC(ctx, msg.HashLock, msg.Secret); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgRefundHTLC(ctx sdk.Context, k Keeper, msg types.MsgRefundHTLC) (*sdk.Result, error) {
+	if err := k.RefundHTLC(ctx, msg.HashLock); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgCreateRoutedHTLC(ctx sdk.Context, k Keeper, msg types.MsgCreateRoutedHTLC) (*sdk.Result, error) {
+	if err := k.CreateRoutedHTLC(ctx, msg.Sender, msg.Route, msg.HashLock, msg.HashAlgo, msg.Timestamp, msg.TimeLock); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
+
+func handleMsgForwardHTLC(ctx sdk.Context, k Keeper, msg types.MsgForwardHTLC) (*sdk.Result, error) {
+	if err := k.ForwardHTLC(ctx, msg.Forwarder, msg.HashLock, msg.HopIndex); err != nil {
+		return nil, err
+	}
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil
+}
@@ -0,0 +1,250 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	channel "github.com/cosmos/cosmos-sdk/x/ibc/04-channel"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	channelexported "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/exported"
+
+	"github.com/irismod/htlc/types"
+)
+
+// OnChanOpenInit validates that a channel opening to the htlc module uses
+// the unordered ordering and the htlc packet version
+func (k Keeper) OnChanOpenInit(
+	order channelexported.Order,
+	connectionHops []string,
+	portID, channelID string,
+	channelCap *channelexported.Capability,
+	counterparty channeltypes.Counterparty,
+	version string,
+) error {
+	if order != channelexported.UNORDERED {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelOrdering, "expected %s channel, got %s", channelexported.UNORDERED, order)
+	}
+
+	if portID != types.PortID {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelPort, "expected %s, got %s", types.PortID, portID)
+	}
+
+	if version != types.Version {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelVersion, "expected %s, got %s", types.Version, version)
+	}
+
+	return k.ClaimCapability(channelCap, channel.ChannelCapabilityPath(portID, channelID))
+}
+
+// OnChanOpenTry validates the counterparty-proposed version during the
+// try step of the channel handshake
+func (k Keeper) OnChanOpenTry(
+	order channelexported.Order,
+	connectionHops []string,
+	portID, channelID string,
+	channelCap *channelexported.Capability,
+	counterparty channeltypes.Counterparty,
+	version, counterpartyVersion string,
+) error {
+	if order != channelexported.UNORDERED {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelOrdering, "expected %s channel, got %s", channelexported.UNORDERED, order)
+	}
+
+	if portID != types.PortID {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelPort, "expected %s, got %s", types.PortID, portID)
+	}
+
+	if counterpartyVersion != types.Version {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelVersion, "expected %s, got %s", types.Version, counterpartyVersion)
+	}
+
+	return k.ClaimCapability(channelCap, channel.ChannelCapabilityPath(portID, channelID))
+}
+
+// OnChanOpenAck rejects a channel whose counterparty negotiated an
+// unsupported packet version
+func (k Keeper) OnChanOpenAck(portID, channelID string, counterpartyVersion string) error {
+	if counterpartyVersion != types.Version {
+		return sdkerrors.Wrapf(channeltypes.ErrInvalidChannelVersion, "expected %s, got %s", types.Version, counterpartyVersion)
+	}
+	return nil
+}
+
+// OnChanOpenConfirm is a no-op; the handshake is already validated by the
+// time this step is reached
+func (k Keeper) OnChanOpenConfirm(portID, channelID string) error {
+	return nil
+}
+
+// OnChanCloseInit disallows user-initiated channel closure; htlc channels
+// are only closed when the counterparty closes theirs
+func (k Keeper) OnChanCloseInit(portID, channelID string) error {
+	return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "user cannot close htlc channel")
+}
+
+// OnChanCloseConfirm is a no-op
+func (k Keeper) OnChanCloseConfirm(portID, channelID string) error {
+	return nil
+}
+
+// OnRecvPacket is the PacketHandler for the htlc module. It decodes the
+// packet data and opens a mirrored HTLC on this chain, funded by the
+// counterparty's own account rather than the originating sender: an atomic
+// swap's destination leg must be escrowed by whoever holds funds on this
+// chain, not debited a second time from the account that funded the origin
+// leg.
+func (k Keeper) OnRecvPacket(ctx sdk.Context, packet channeltypes.Packet) (*sdk.Result, []byte, error) {
+	var data types.HTLCPacketData
+	if err := types.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return nil, nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if err := data.ValidateBasic(); err != nil {
+		return nil, nil, err
+	}
+
+	// data.TimeLock is relative, same as MsgCreateHTLC's; the mirrored
+	// HTLC's ExpireHeight must be absolute, measured from this chain's own
+	// block height since it is opened independently of the origin leg
+	expireHeight := uint64(ctx.BlockHeight()) + data.TimeLock
+
+	htlc := types.NewHTLCWithAlgo(
+		data.CounterpartyFunder, data.Sender, data.ReceiverOnOtherChain, data.Amount,
+		data.HashLock, data.HashAlgo, data.Timestamp, expireHeight, types.Open,
+	)
+
+	// Route the mirror through CreateHTLC, not a bare SetHTLC: it still has
+	// to escrow the counterparty's funds in the module account and write the
+	// sender/receiver/state/expiration indexes like any other HTLC, so it
+	// can be claimed, refunded and queried the same way.
+	if err := k.CreateHTLC(ctx, htlc); err != nil {
+		return nil, nil, err
+	}
+
+	k.SetIBCHTLC(ctx, types.NewIBCHTLC(data.HashLock, packet.GetDestPort(), packet.GetDestChannel(), packet.GetSequence(), false))
+
+	return &sdk.Result{Events: ctx.EventManager().ABCIEvents()}, nil, nil
+}
+
+// OnAcknowledgementPacket processes the acknowledgement of a htlc packet
+// sent by this chain. A PacketClaimHTLC acknowledgement means the mirrored
+// HTLC on the counterparty chain was claimed, so the origin HTLC is claimed
+// here with the same secret to complete the swap.
+func (k Keeper) OnAcknowledgementPacket(ctx sdk.Context, packet channeltypes.Packet, ack channeltypes.Acknowledgement) error {
+	if !ack.Success() {
+		return k.timeoutHTLC(ctx, packet)
+	}
+
+	var claim types.PacketClaimHTLC
+	if err := types.ModuleCdc.UnmarshalJSON(ack.GetResult(), &claim); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	return k.ClaimHTLC(ctx, claim.HashLock, claim.Secret)
+}
+
+// OnTimeoutPacket is called when the counterparty chain has not relayed the
+// packet before the timeout height or timestamp. The locally created HTLC
+// is refunded back to the sender using the existing refund path.
+func (k Keeper) OnTimeoutPacket(ctx sdk.Context, packet channeltypes.Packet) error {
+	return k.timeoutHTLC(ctx, packet)
+}
+
+// timeoutHTLC decodes the original packet data and refunds the HTLC that
+// was locked on this chain when the packet was sent
+func (k Keeper) timeoutHTLC(ctx sdk.Context, packet channeltypes.Packet) error {
+	var data types.HTLCPacketData
+	if err := types.ModuleCdc.UnmarshalJSON(packet.GetData(), &data); err != nil {
+		return sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	return k.RefundHTLC(ctx, data.HashLock)
+}
+
+// SendHTLCPacket sends an HTLCPacketData packet over the given channel,
+// opening a mirrored HTLC on the counterparty chain. It is called by
+// SendCrossChainHTLC once the local leg of the swap has been locked.
+func (k Keeper) SendHTLCPacket(
+	ctx sdk.Context,
+	sourcePort, sourceChannel string,
+	timeoutHeight channeltypes.Height,
+	timeoutTimestamp uint64,
+	data types.HTLCPacketData,
+) error {
+	sourceChannelEnd, found := k.channelKeeper.GetChannel(ctx, sourcePort, sourceChannel)
+	if !found {
+		return sdkerrors.Wrap(channeltypes.ErrChannelNotFound, sourceChannel)
+	}
+
+	destinationPort := sourceChannelEnd.GetCounterparty().GetPortID()
+	destinationChannel := sourceChannelEnd.GetCounterparty().GetChannelID()
+
+	channelCap, ok := k.scopedKeeper.GetCapability(ctx, channel.ChannelCapabilityPath(sourcePort, sourceChannel))
+	if !ok {
+		return sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+
+	packet := channeltypes.NewPacket(
+		data.GetBytes(),
+		k.channelKeeper.GetNextSequenceSend(ctx, sourcePort, sourceChannel),
+		sourcePort, sourceChannel,
+		destinationPort, destinationChannel,
+		timeoutHeight, timeoutTimestamp,
+	)
+
+	return k.channelKeeper.SendPacket(ctx, channelCap, packet)
+}
+
+// SendCrossChainHTLC sends htlc's counterparty leg as an HTLCPacketData over
+// destChannel, and records the outgoing IBCHTLC link on the sending side so
+// that acknowledgement and timeout handling can route back to htlc the same
+// way OnRecvPacket does for the mirrored HTLC it opens on the other chain.
+// timeLock is the same relative time lock htlc.ExpireHeight was derived
+// from; it is sent on the packet as-is, and used again here to compute the
+// packet's own timeout so it lines up with htlc's absolute expire height.
+func (k Keeper) SendCrossChainHTLC(ctx sdk.Context, destChannel string, htlc types.HTLC, timeLock uint64) error {
+	sequence := k.channelKeeper.GetNextSequenceSend(ctx, types.PortID, destChannel)
+
+	// ReceiverOnOtherChain names the account on the destination chain that
+	// must fund the mirrored HTLC there; it is the counterparty's address,
+	// not the originating sender's
+	counterpartyFunder, err := sdk.AccAddressFromBech32(htlc.ReceiverOnOtherChain)
+	if err != nil {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "receiver on other chain %s is not a valid address: %s", htlc.ReceiverOnOtherChain, err)
+	}
+
+	data := types.NewHTLCPacketData(
+		htlc.Sender, htlc.To, htlc.ReceiverOnOtherChain, counterpartyFunder, htlc.Amount,
+		htlc.HashLock, htlc.HashAlgo, htlc.Timestamp, timeLock,
+	)
+
+	timeoutHeight := channeltypes.NewHeight(0, uint64(ctx.BlockHeight())+timeLock)
+
+	if err := k.SendHTLCPacket(ctx, types.PortID, destChannel, timeoutHeight, 0, data); err != nil {
+		return err
+	}
+
+	k.SetIBCHTLC(ctx, types.NewIBCHTLC(htlc.HashLock, types.PortID, destChannel, sequence, true))
+	return nil
+}
+
+// writeClaimAcknowledgement writes a PacketClaimHTLC acknowledgement for the
+// packet that opened the mirrored HTLC identified by link, carrying the
+// secret back to the initiator so it can claim its own leg of the swap on
+// the origin chain once OnAcknowledgementPacket processes it there.
+func (k Keeper) writeClaimAcknowledgement(ctx sdk.Context, link types.IBCHTLC, secret []byte) error {
+	channelCap, ok := k.scopedKeeper.GetCapability(ctx, channel.ChannelCapabilityPath(link.PortID, link.ChannelID))
+	if !ok {
+		return sdkerrors.Wrap(channeltypes.ErrChannelCapabilityNotFound, "module does not own channel capability")
+	}
+
+	packet := channeltypes.NewPacket(
+		nil, link.Sequence,
+		"", "",
+		link.PortID, link.ChannelID,
+		channeltypes.NewHeight(0, 0), 0,
+	)
+
+	ack := channeltypes.NewResultAcknowledgement(types.NewPacketClaimHTLC(link.HashLock, secret).GetBytes())
+
+	return k.channelKeeper.WriteAcknowledgement(ctx, channelCap, packet, ack.GetBytes())
+}
@@ -0,0 +1,43 @@
+package keeper_test
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irismod/htlc/types"
+)
+
+func TestOnRecvPacketEscrowsFundsAndWritesIndexes(t *testing.T) {
+	k, ctx, bank := setupTestKeeper(t)
+
+	sender := sdk.AccAddress("sender_____________")
+	to := sdk.AccAddress("recipient__________")
+	counterpartyFunder := sdk.AccAddress("counterparty_funder")
+	amount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	hashLock := make([]byte, types.HashLockLength)
+
+	bank.balances[counterpartyFunder.String()] = amount
+
+	data := types.NewHTLCPacketData(sender, to, "", counterpartyFunder, amount, hashLock, types.SHA256, 0, 100)
+	packet := channeltypes.NewPacket(
+		data.GetBytes(), 1, types.PortID, "channel-0", types.PortID, "channel-1",
+		channeltypes.NewHeight(0, 1000), 0,
+	)
+
+	_, _, err := k.OnRecvPacket(ctx, packet)
+	require.NoError(t, err)
+
+	// the mirrored HTLC must have escrowed the counterparty funder's funds
+	// into the module account, not the originating sender's
+	require.True(t, bank.balances[counterpartyFunder.String()].IsZero())
+	require.True(t, bank.balances[types.ModuleName].IsEqual(amount))
+
+	// and it must be visible to the secondary indexes used by FilterHTLCs,
+	// with the counterparty funder as the sender of the mirrored leg
+	filtered := k.FilterHTLCs(ctx, types.NewQueryHTLCsParams(types.Open, false, counterpartyFunder, nil, 1, 100))
+	require.Len(t, filtered, 1)
+	require.True(t, filtered[0].HashLock != nil)
+}
@@ -0,0 +1,303 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	capabilitykeeper "github.com/cosmos/cosmos-sdk/x/capability/keeper"
+	capabilitytypes "github.com/cosmos/cosmos-sdk/x/capability/types"
+	channeltypes "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/types"
+	channelexported "github.com/cosmos/cosmos-sdk/x/ibc/04-channel/exported"
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+
+	"github.com/irismod/htlc/types"
+)
+
+// ChannelKeeper defines the expected IBC channel keeper used to send and
+// receive HTLC packets
+type ChannelKeeper interface {
+	GetChannel(ctx sdk.Context, srcPort, srcChan string) (channeltypes.Channel, bool)
+	GetNextSequenceSend(ctx sdk.Context, portID, channelID string) uint64
+	SendPacket(ctx sdk.Context, channelCap *channelexported.Capability, packet channelexported.PacketI) error
+	WriteAcknowledgement(ctx sdk.Context, channelCap *channelexported.Capability, packet channelexported.PacketI, acknowledgement []byte) error
+}
+
+// PortKeeper defines the expected IBC port keeper used to bind the htlc
+// module to its dedicated port
+type PortKeeper interface {
+	BindPort(ctx sdk.Context, portID string) *capabilitytypes.Capability
+}
+
+// Keeper manages HTLC state in the store
+type Keeper struct {
+	cdc      *codec.Codec
+	storeKey sdk.StoreKey
+
+	bankKeeper BankKeeper
+
+	channelKeeper ChannelKeeper
+	portKeeper    PortKeeper
+	scopedKeeper  capabilitykeeper.ScopedKeeper
+
+	paramSpace paramtypes.Subspace
+}
+
+// BankKeeper defines the expected bank keeper used to move coins in and out
+// of HTLCs
+type BankKeeper interface {
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// NewKeeper constructs a new HTLC Keeper instance
+func NewKeeper(
+	cdc *codec.Codec,
+	storeKey sdk.StoreKey,
+	paramSpace paramtypes.Subspace,
+	bankKeeper BankKeeper,
+	channelKeeper ChannelKeeper,
+	portKeeper PortKeeper,
+	scopedKeeper capabilitykeeper.ScopedKeeper,
+) Keeper {
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		cdc:           cdc,
+		storeKey:      storeKey,
+		paramSpace:    paramSpace,
+		bankKeeper:    bankKeeper,
+		channelKeeper: channelKeeper,
+		portKeeper:    portKeeper,
+		scopedKeeper:  scopedKeeper,
+	}
+}
+
+// GetParams fetches the current htlc module parameters
+func (k Keeper) GetParams(ctx sdk.Context) types.Params {
+	var params types.Params
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the htlc module parameters
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
+
+// BindPort binds the htlc module to its dedicated IBC port, returning the
+// capability that OnChanOpenInit/Try later claim
+func (k Keeper) BindPort(ctx sdk.Context) *capabilitytypes.Capability {
+	return k.portKeeper.BindPort(ctx, types.PortID)
+}
+
+// ClaimCapability wraps the scoped keeper's ClaimCapability so IBC callback
+// handlers in this package don't need direct access to the scoped keeper
+func (k Keeper) ClaimCapability(cap *channelexported.Capability, name string) error {
+	return k.scopedKeeper.ClaimCapability(cap, name)
+}
+
+// htlcStoreKey returns the store key an HTLC is stored under, keyed by its
+// hash lock
+func htlcStoreKey(hashLock []byte) []byte {
+	return append([]byte{0x01}, hashLock...)
+}
+
+// ibcHTLCStoreKey returns the store key an IBCHTLC link is stored under
+func ibcHTLCStoreKey(hashLock []byte) []byte {
+	return append([]byte{0x02}, hashLock...)
+}
+
+// Store key prefixes. prefixHTLC and prefixIBCHTLC hold the primary HTLC and
+// IBCHTLC records. The remaining prefixes are secondary indexes: each stores
+// an empty value and exists only so querier.go can iterate HTLCs by sender,
+// receiver or state without scanning the full primary store.
+const (
+	prefixHTLC       = 0x01
+	prefixIBCHTLC    = 0x02
+	prefixBySender   = 0x03
+	prefixByReceiver = 0x04
+	prefixByState    = 0x05
+)
+
+func bySenderKey(sender sdk.AccAddress, hashLock []byte) []byte {
+	key := []byte{prefixBySender}
+	key = append(key, sender.Bytes()...)
+	return append(key, hashLock...)
+}
+
+func byReceiverKey(receiver sdk.AccAddress, hashLock []byte) []byte {
+	key := []byte{prefixByReceiver}
+	key = append(key, receiver.Bytes()...)
+	return append(key, hashLock...)
+}
+
+func byStateKey(state types.HTLCState, hashLock []byte) []byte {
+	key := []byte{prefixByState, byte(state)}
+	return append(key, hashLock...)
+}
+
+// setIndexes writes the sender, receiver and state index entries for htlc
+func (k Keeper) setIndexes(ctx sdk.Context, htlc types.HTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(bySenderKey(htlc.Sender, htlc.HashLock), []byte{})
+	store.Set(byReceiverKey(htlc.To, htlc.HashLock), []byte{})
+	store.Set(byStateKey(htlc.State, htlc.HashLock), []byte{})
+}
+
+// removeStateIndex deletes the state index entry for htlc under oldState,
+// used when a claim or refund moves an HTLC to a new state
+func (k Keeper) removeStateIndex(ctx sdk.Context, oldState types.HTLCState, htlc types.HTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(byStateKey(oldState, htlc.HashLock))
+}
+
+// GetHTLC fetches the HTLC with the given hash lock
+func (k Keeper) GetHTLC(ctx sdk.Context, hashLock []byte) (types.HTLC, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(htlcStoreKey(hashLock))
+	if bz == nil {
+		return types.HTLC{}, false
+	}
+
+	var htlc types.HTLC
+	k.cdc.MustUnmarshalBinaryBare(bz, &htlc)
+	return htlc, true
+}
+
+// SetHTLC persists an HTLC, keyed by its hash lock
+func (k Keeper) SetHTLC(ctx sdk.Context, htlc types.HTLC) error {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(htlcStoreKey(htlc.HashLock), k.cdc.MustMarshalBinaryBare(htlc))
+	return nil
+}
+
+// SetIBCHTLC persists the IBC channel an HTLC was opened over, so a later
+// claim or timeout can be routed back to the right packet
+func (k Keeper) SetIBCHTLC(ctx sdk.Context, link types.IBCHTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(ibcHTLCStoreKey(link.HashLock), k.cdc.MustMarshalBinaryBare(link))
+}
+
+// GetIBCHTLC fetches the IBC channel link for an HTLC, if any
+func (k Keeper) GetIBCHTLC(ctx sdk.Context, hashLock []byte) (types.IBCHTLC, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(ibcHTLCStoreKey(hashLock))
+	if bz == nil {
+		return types.IBCHTLC{}, false
+	}
+
+	var link types.IBCHTLC
+	k.cdc.MustUnmarshalBinaryBare(bz, &link)
+	return link, true
+}
+
+// CreateHTLC locks the sender's coins in the module account and stores a
+// new open HTLC under its hash lock
+func (k Keeper) CreateHTLC(ctx sdk.Context, htlc types.HTLC) error {
+	if _, ok := k.GetHTLC(ctx, htlc.HashLock); ok {
+		return sdkerrors.Wrap(types.ErrHTLCAlreadyExists, string(htlc.HashLock))
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, htlc.Sender, types.ModuleName, htlc.Amount); err != nil {
+		return err
+	}
+
+	if err := k.SetHTLC(ctx, htlc); err != nil {
+		return err
+	}
+
+	k.setIndexes(ctx, htlc)
+	return nil
+}
+
+// ClaimHTLC verifies the secret against the HTLC's hash lock using the
+// hash algorithm the HTLC was created with, then releases the locked coins
+// to the recipient
+func (k Keeper) ClaimHTLC(ctx sdk.Context, hashLock, secret []byte) error {
+	htlc, ok := k.GetHTLC(ctx, hashLock)
+	if !ok {
+		if route, isRouted := k.GetRoute(ctx, hashLock); isRouted {
+			return k.ClaimRouteHop(ctx, route, hashLock, secret)
+		}
+		return sdkerrors.Wrap(types.ErrUnknownHTLC, string(hashLock))
+	}
+
+	if htlc.State != types.Open {
+		return sdkerrors.Wrap(types.ErrHTLCNotOpen, string(hashLock))
+	}
+
+	computed := types.GetHashLockByAlgo(secret, htlc.Timestamp, htlc.HashAlgo)
+	if !bytesEqual(computed, htlc.HashLock) {
+		return sdkerrors.Wrap(types.ErrInvalidSecret, "secret does not match the hash lock")
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.To, htlc.Amount); err != nil {
+		return err
+	}
+
+	// if this HTLC is the mirrored leg of a cross-chain swap, the initiator
+	// is waiting on an acknowledgement carrying the secret before it can
+	// claim its own leg on the origin chain
+	if link, ok := k.GetIBCHTLC(ctx, hashLock); ok && !link.Initiator {
+		if err := k.writeClaimAcknowledgement(ctx, link, secret); err != nil {
+			return err
+		}
+	}
+
+	return k.transitionState(ctx, htlc, types.Completed)
+}
+
+// RefundHTLC returns a timed-out HTLC's coins back to its sender. It is
+// gated on the HTLC's expire height so that a refund can never race ahead
+// of the receiver's chance to claim.
+func (k Keeper) RefundHTLC(ctx sdk.Context, hashLock []byte) error {
+	htlc, ok := k.GetHTLC(ctx, hashLock)
+	if !ok {
+		if route, isRouted := k.GetRoute(ctx, hashLock); isRouted {
+			return k.RefundRoute(ctx, route, hashLock)
+		}
+		return sdkerrors.Wrap(types.ErrUnknownHTLC, string(hashLock))
+	}
+
+	if htlc.State != types.Open {
+		return sdkerrors.Wrap(types.ErrHTLCNotOpen, string(hashLock))
+	}
+
+	if uint64(ctx.BlockHeight()) < htlc.ExpireHeight {
+		return sdkerrors.Wrapf(types.ErrHTLCNotExpired, "current height %d, expires at %d", ctx.BlockHeight(), htlc.ExpireHeight)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, htlc.Amount); err != nil {
+		return err
+	}
+
+	return k.transitionState(ctx, htlc, types.Refunded)
+}
+
+// transitionState moves htlc to newState, persisting it and updating the
+// state secondary index accordingly
+func (k Keeper) transitionState(ctx sdk.Context, htlc types.HTLC, newState types.HTLCState) error {
+	k.removeStateIndex(ctx, htlc.State, htlc)
+	htlc.State = newState
+	if err := k.SetHTLC(ctx, htlc); err != nil {
+		return err
+	}
+	ctx.KVStore(k.storeKey).Set(byStateKey(htlc.State, htlc.HashLock), []byte{})
+	return nil
+}
+
+func bytesEqual(a, b []byte) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,135 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/irismod/htlc/types"
+)
+
+// NewQuerier creates a new querier for htlc clients
+func NewQuerier(k Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
+		switch path[0] {
+		case types.QueryHTLC:
+			return queryHTLC(ctx, req, k)
+		case types.QueryHTLCs:
+			return queryHTLCs(ctx, req, k)
+		case types.QueryParams:
+			return queryParams(ctx, k)
+		default:
+			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown htlc query endpoint: %s", path[0])
+		}
+	}
+}
+
+func queryHTLC(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryHTLCParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	htlc, ok := k.GetHTLC(ctx, params.HashLock)
+	if !ok {
+		return nil, sdkerrors.Wrap(types.ErrUnknownHTLC, fmt.Sprintf("%x", params.HashLock))
+	}
+
+	bz, err := types.ModuleCdc.MarshalJSON(htlc)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryHTLCs(ctx sdk.Context, req abci.RequestQuery, k Keeper) ([]byte, error) {
+	var params types.QueryHTLCsParams
+	if err := types.ModuleCdc.UnmarshalJSON(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	htlcs := k.FilterHTLCs(ctx, params)
+
+	start, end := client.Paginate(len(htlcs), params.Page, params.Limit, 100)
+	if start < 0 || end < 0 {
+		htlcs = []types.HTLC{}
+	} else {
+		htlcs = htlcs[start:end]
+	}
+
+	bz, err := types.ModuleCdc.MarshalJSON(htlcs)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryParams(ctx sdk.Context, k Keeper) ([]byte, error) {
+	bz, err := types.ModuleCdc.MarshalJSON(k.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+// FilterHTLCs returns every HTLC matching the given filter, using the
+// sender/receiver/state secondary indexes instead of a full table scan
+// whenever the filter narrows the search enough to make that worthwhile
+func (k Keeper) FilterHTLCs(ctx sdk.Context, params types.QueryHTLCsParams) []types.HTLC {
+	var hashLocks [][]byte
+
+	switch {
+	case len(params.Sender) > 0:
+		hashLocks = k.hashLocksByPrefix(ctx, append([]byte{prefixBySender}, params.Sender.Bytes()...))
+	case len(params.Receiver) > 0:
+		hashLocks = k.hashLocksByPrefix(ctx, append([]byte{prefixByReceiver}, params.Receiver.Bytes()...))
+	case params.HasState():
+		hashLocks = k.hashLocksByPrefix(ctx, []byte{prefixByState, byte(*params.State)})
+	default:
+		hashLocks = k.hashLocksByPrefix(ctx, []byte{prefixHTLC})
+	}
+
+	htlcs := make([]types.HTLC, 0, len(hashLocks))
+	for _, hashLock := range hashLocks {
+		htlc, ok := k.GetHTLC(ctx, hashLock)
+		if !ok {
+			continue
+		}
+
+		if len(params.Sender) > 0 && !htlc.Sender.Equals(params.Sender) {
+			continue
+		}
+		if len(params.Receiver) > 0 && !htlc.To.Equals(params.Receiver) {
+			continue
+		}
+		if params.HasState() && htlc.State != *params.State {
+			continue
+		}
+
+		htlcs = append(htlcs, htlc)
+	}
+
+	return htlcs
+}
+
+// hashLocksByPrefix collects the hash lock suffix of every key under
+// prefix, which is how each secondary index stores its entries
+func (k Keeper) hashLocksByPrefix(ctx sdk.Context, prefix []byte) [][]byte {
+	store := ctx.KVStore(k.storeKey)
+	iterator := sdk.KVStorePrefixIterator(store, prefix)
+	defer iterator.Close()
+
+	var hashLocks [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		hashLocks = append(hashLocks, key[len(prefix):])
+	}
+
+	return hashLocks
+}
@@ -0,0 +1,233 @@
+package keeper
+
+import (
+	"encoding/binary"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/irismod/htlc/types"
+)
+
+const (
+	prefixRoute    = 0x07
+	prefixRouteHop = 0x08
+)
+
+func routeStoreKey(hashLock []byte) []byte {
+	return append([]byte{prefixRoute}, hashLock...)
+}
+
+func routeHopStoreKey(hashLock []byte, hopIndex uint32) []byte {
+	key := make([]byte, 1+len(hashLock)+4)
+	key[0] = prefixRouteHop
+	copy(key[1:], hashLock)
+	binary.BigEndian.PutUint32(key[1+len(hashLock):], hopIndex)
+	return key
+}
+
+// SetRoute persists the immutable route definition of a routed HTLC
+func (k Keeper) SetRoute(ctx sdk.Context, route types.RoutedHTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(routeStoreKey(route.HashLock), k.cdc.MustMarshalBinaryBare(route))
+}
+
+// GetRoute fetches the route definition for a routed HTLC's shared hash lock
+func (k Keeper) GetRoute(ctx sdk.Context, hashLock []byte) (types.RoutedHTLC, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(routeStoreKey(hashLock))
+	if bz == nil {
+		return types.RoutedHTLC{}, false
+	}
+
+	var route types.RoutedHTLC
+	k.cdc.MustUnmarshalBinaryBare(bz, &route)
+	return route, true
+}
+
+// SetHopHTLC persists the HTLC locking a single hop of a route
+func (k Keeper) SetHopHTLC(ctx sdk.Context, hashLock []byte, hopIndex uint32, htlc types.HTLC) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(routeHopStoreKey(hashLock, hopIndex), k.cdc.MustMarshalBinaryBare(htlc))
+}
+
+// GetHopHTLC fetches the HTLC locking a single hop of a route
+func (k Keeper) GetHopHTLC(ctx sdk.Context, hashLock []byte, hopIndex uint32) (types.HTLC, bool) {
+	store := ctx.KVStore(k.storeKey)
+
+	bz := store.Get(routeHopStoreKey(hashLock, hopIndex))
+	if bz == nil {
+		return types.HTLC{}, false
+	}
+
+	var htlc types.HTLC
+	k.cdc.MustUnmarshalBinaryBare(bz, &htlc)
+	return htlc, true
+}
+
+// CreateRoutedHTLC locks the sender's coins to the first hop's forwarder
+// with the full time lock, and records the route so later hops can be
+// opened by MsgForwardHTLC and the whole chain can be claimed or refunded
+func (k Keeper) CreateRoutedHTLC(
+	ctx sdk.Context,
+	sender sdk.AccAddress,
+	route []types.Hop,
+	hashLock []byte,
+	hashAlgo types.HashAlgo,
+	timestamp, timeLock uint64,
+) error {
+	if _, ok := k.GetRoute(ctx, hashLock); ok {
+		return sdkerrors.Wrap(types.ErrHTLCAlreadyExists, string(hashLock))
+	}
+
+	firstHop := route[0]
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, sender, types.ModuleName, firstHop.Amount); err != nil {
+		return err
+	}
+
+	k.SetRoute(ctx, types.NewRoutedHTLC(sender, route, hashLock, hashAlgo, timestamp))
+
+	// timeLock is relative, same as MsgCreateHTLC's; the first hop's
+	// ExpireHeight must be absolute like every other hop's
+	expireHeight := uint64(ctx.BlockHeight()) + timeLock
+	firstHTLC := types.NewHTLCWithAlgo(sender, firstHop.Forwarder, "", firstHop.Amount, hashLock, hashAlgo, timestamp, expireHeight, types.Open)
+	k.SetHopHTLC(ctx, hashLock, 0, firstHTLC)
+
+	return nil
+}
+
+// ForwardHTLC is called by an intermediate forwarder to open the next hop
+// of a routed HTLC it currently holds, enforcing that the outgoing
+// timelock and amount respect the route's declared per-hop invariants
+func (k Keeper) ForwardHTLC(ctx sdk.Context, forwarder sdk.AccAddress, hashLock []byte, hopIndex uint32) error {
+	route, ok := k.GetRoute(ctx, hashLock)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrUnknownHTLC, string(hashLock))
+	}
+
+	incoming, ok := k.GetHopHTLC(ctx, hashLock, hopIndex)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrUnknownHTLC, string(hashLock))
+	}
+
+	if !incoming.To.Equals(forwarder) {
+		return sdkerrors.Wrap(sdkerrors.ErrUnauthorized, "only the current hop's forwarder can forward the HTLC")
+	}
+
+	if incoming.State != types.Open {
+		return sdkerrors.Wrap(types.ErrHTLCNotOpen, string(hashLock))
+	}
+
+	nextHopIndex := hopIndex + 1
+	if int(nextHopIndex) >= len(route.Route) {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "no further hops in this route; the final recipient should claim directly")
+	}
+
+	hop := route.Route[nextHopIndex]
+
+	// guard the subtraction below before it runs: ExpireHeight is unsigned,
+	// so a delta that reaches or exceeds it would either underflow to a huge
+	// height or land exactly on zero, an outgoing time lock HTLC.Validate
+	// itself rejects but that nothing here ever validates
+	if hop.TimeLockDelta >= incoming.ExpireHeight {
+		return sdkerrors.Wrapf(types.ErrInvalidTimeLock, "hop time lock delta %d must be strictly less than the incoming time lock %d", hop.TimeLockDelta, incoming.ExpireHeight)
+	}
+	outgoingTimeLock := incoming.ExpireHeight - hop.TimeLockDelta
+
+	if err := types.ValidateHop(incoming.Amount, incoming.ExpireHeight, hop, outgoingTimeLock); err != nil {
+		return err
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, forwarder, types.ModuleName, hop.Amount); err != nil {
+		return err
+	}
+
+	outgoing := types.NewHTLCWithAlgo(forwarder, hop.Forwarder, "", hop.Amount, hashLock, route.HashAlgo, incoming.Timestamp, outgoingTimeLock, types.Open)
+	k.SetHopHTLC(ctx, hashLock, nextHopIndex, outgoing)
+
+	return nil
+}
+
+// RefundRoute refunds every still-open, already-expired hop of a routed
+// HTLC back to whichever account funded it, used when the route times out
+// before the final recipient claims. Unlike ClaimRouteHop, there is no
+// cascade: each hop was funded by a different account (the original sender
+// for hop 0, the previous hop's forwarder for every hop after), so each is
+// refunded independently, and each only once its own expire height (which
+// decreases hop by hop) has passed.
+func (k Keeper) RefundRoute(ctx sdk.Context, route types.RoutedHTLC, hashLock []byte) error {
+	refundedAny := false
+
+	for hopIndex := range route.Route {
+		htlc, ok := k.GetHopHTLC(ctx, hashLock, uint32(hopIndex))
+		if !ok {
+			// later hops were never opened either, since ForwardHTLC opens
+			// them in order
+			break
+		}
+
+		if htlc.State != types.Open {
+			continue
+		}
+
+		if uint64(ctx.BlockHeight()) < htlc.ExpireHeight {
+			continue
+		}
+
+		if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.Sender, htlc.Amount); err != nil {
+			return err
+		}
+
+		htlc.State = types.Refunded
+		k.SetHopHTLC(ctx, hashLock, uint32(hopIndex), htlc)
+		refundedAny = true
+	}
+
+	if !refundedAny {
+		return sdkerrors.Wrap(types.ErrHTLCNotOpen, string(hashLock))
+	}
+
+	return nil
+}
+
+// ClaimRouteHop claims the final hop of a routed HTLC with the revealed
+// secret, then cascades back up the route auto-claiming every upstream hop
+// on the forwarders' behalf so honest forwarders are always reimbursed
+func (k Keeper) ClaimRouteHop(ctx sdk.Context, route types.RoutedHTLC, hashLock, secret []byte) error {
+	return k.claimHopRecursive(ctx, route, hashLock, secret, uint32(len(route.Route)-1))
+}
+
+func (k Keeper) claimHopRecursive(ctx sdk.Context, route types.RoutedHTLC, hashLock, secret []byte, hopIndex uint32) error {
+	htlc, ok := k.GetHopHTLC(ctx, hashLock, hopIndex)
+	if !ok {
+		return sdkerrors.Wrap(types.ErrUnknownHTLC, string(hashLock))
+	}
+
+	if htlc.State != types.Open {
+		return sdkerrors.Wrap(types.ErrHTLCNotOpen, string(hashLock))
+	}
+
+	computed := types.GetHashLockByAlgo(secret, route.Timestamp, route.HashAlgo)
+	if !bytesEqual(computed, hashLock) {
+		return sdkerrors.Wrap(types.ErrInvalidSecret, "secret does not match the hash lock")
+	}
+
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, htlc.To, htlc.Amount); err != nil {
+		return err
+	}
+
+	htlc.State = types.Completed
+	k.SetHopHTLC(ctx, hashLock, hopIndex, htlc)
+
+	if hopIndex == 0 {
+		return nil
+	}
+
+	// The upstream leg is reimbursed automatically: the forwarder that
+	// opened this hop is the recipient of the previous hop, which is claimed
+	// below on its behalf, so it never needs to watch the chain and submit
+	// its own claim.
+	return k.claimHopRecursive(ctx, route, hashLock, secret, hopIndex-1)
+}
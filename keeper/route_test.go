@@ -0,0 +1,127 @@
+package keeper_test
+
+import (
+	"bytes"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/irismod/htlc/types"
+)
+
+func TestForwardHTLCRejectsAHopDeltaThatWouldZeroTheOutgoingTimeLock(t *testing.T) {
+	k, ctx, bank := setupTestKeeper(t)
+
+	sender := sdk.AccAddress("sender_____________")
+	forwarder := sdk.AccAddress("forwarder__________")
+	finalRecipient := sdk.AccAddress("final_recipient____")
+	hashLock := make([]byte, types.HashLockLength)
+
+	firstHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	secondHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 90))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+
+	// the hop's time lock delta exactly equals the incoming leg's absolute
+	// expire height (block height 1 + time lock 49 = 50), which would
+	// previously subtract to exactly zero and build an outgoing HTLC with
+	// ExpireHeight == 0, a value HTLC.Validate rejects but that ForwardHTLC
+	// never validates
+	route := []types.Hop{
+		types.NewHop(forwarder, firstHopAmount, sdk.NewCoins(), 50),
+		types.NewHop(finalRecipient, secondHopAmount, fee, 50),
+	}
+
+	bank.balances[sender.String()] = firstHopAmount
+	require.NoError(t, k.CreateRoutedHTLC(ctx, sender, route, hashLock, types.SHA256, 0, 49))
+
+	bank.balances[forwarder.String()] = secondHopAmount
+	require.Error(t, k.ForwardHTLC(ctx, forwarder, hashLock, 0))
+}
+
+func TestRefundHTLCRefundsEveryOpenHopOfARoute(t *testing.T) {
+	k, ctx, bank := setupTestKeeper(t)
+
+	sender := sdk.AccAddress("sender_____________")
+	forwarder := sdk.AccAddress("forwarder__________")
+	finalRecipient := sdk.AccAddress("final_recipient____")
+	hashLock := make([]byte, types.HashLockLength)
+
+	firstHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	secondHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 90))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+
+	route := []types.Hop{
+		types.NewHop(forwarder, firstHopAmount, sdk.NewCoins(), 50),
+		types.NewHop(finalRecipient, secondHopAmount, fee, 50),
+	}
+
+	bank.balances[sender.String()] = firstHopAmount
+	require.NoError(t, k.CreateRoutedHTLC(ctx, sender, route, hashLock, types.SHA256, 0, 200))
+
+	bank.balances[forwarder.String()] = secondHopAmount
+	require.NoError(t, k.ForwardHTLC(ctx, forwarder, hashLock, 0))
+
+	// both hops' expire heights must actually have passed before either can
+	// be refunded; hop 1 has the smaller expire height (200 - 50 = 150
+	// blocks out), so advance past that too
+	refundCtx := ctx.WithBlockHeight(ctx.BlockHeight() + 200)
+
+	// before this fix, RefundHTLC had no route fallback and every routed
+	// HTLC that timed out returned ErrUnknownHTLC, permanently stranding
+	// both hops' escrowed funds
+	require.NoError(t, k.RefundHTLC(refundCtx, hashLock))
+
+	require.True(t, bank.balances[sender.String()].IsEqual(firstHopAmount))
+	require.True(t, bank.balances[forwarder.String()].IsEqual(secondHopAmount))
+
+	hop0, ok := k.GetHopHTLC(ctx, hashLock, 0)
+	require.True(t, ok)
+	require.Equal(t, types.Refunded, hop0.State)
+
+	hop1, ok := k.GetHopHTLC(ctx, hashLock, 1)
+	require.True(t, ok)
+	require.Equal(t, types.Refunded, hop1.State)
+}
+
+func TestClaimHTLCCascadesUpstreamThroughEveryRouteHop(t *testing.T) {
+	k, ctx, bank := setupTestKeeper(t)
+
+	sender := sdk.AccAddress("sender_____________")
+	forwarder := sdk.AccAddress("forwarder__________")
+	finalRecipient := sdk.AccAddress("final_recipient____")
+
+	secret := bytes.Repeat([]byte{0x42}, types.SecretLength)
+	hashLock := types.GetHashLockByAlgo(secret, 0, types.SHA256)
+
+	firstHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 100))
+	secondHopAmount := sdk.NewCoins(sdk.NewInt64Coin("stake", 90))
+	fee := sdk.NewCoins(sdk.NewInt64Coin("stake", 10))
+
+	route := []types.Hop{
+		types.NewHop(forwarder, firstHopAmount, sdk.NewCoins(), 50),
+		types.NewHop(finalRecipient, secondHopAmount, fee, 50),
+	}
+
+	bank.balances[sender.String()] = firstHopAmount
+	require.NoError(t, k.CreateRoutedHTLC(ctx, sender, route, hashLock, types.SHA256, 0, 200))
+
+	bank.balances[forwarder.String()] = secondHopAmount
+	require.NoError(t, k.ForwardHTLC(ctx, forwarder, hashLock, 0))
+
+	// the final recipient claims with the secret; the forwarder's upstream
+	// leg must be auto-claimed on their behalf so they are reimbursed
+	// without having to watch the chain and submit their own claim
+	require.NoError(t, k.ClaimHTLC(ctx, hashLock, secret))
+
+	require.True(t, bank.balances[finalRecipient.String()].IsEqual(secondHopAmount))
+	require.True(t, bank.balances[forwarder.String()].IsEqual(firstHopAmount))
+
+	hop0, ok := k.GetHopHTLC(ctx, hashLock, 0)
+	require.True(t, ok)
+	require.Equal(t, types.Completed, hop0.State)
+
+	hop1, ok := k.GetHopHTLC(ctx, hashLock, 1)
+	require.True(t, ok)
+	require.Equal(t, types.Completed, hop1.State)
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// RegisterCodec registers the htlc module's interfaces and concrete types
+// on the provided Amino codec
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgCreateHTLC{}, "irismod/htlc/MsgCreateHTLC", nil)
+	cdc.RegisterConcrete(MsgClaimHTLC{}, "irismod/htlc/MsgClaimHTLC", nil)
+	cdc.RegisterConcrete(MsgRefundHTLC{}, "irismod/htlc/MsgRefundHTLC", nil)
+	cdc.RegisterConcrete(MsgCreateRoutedHTLC{}, "irismod/htlc/MsgCreateRoutedHTLC", nil)
+	cdc.RegisterConcrete(MsgForwardHTLC{}, "irismod/htlc/MsgForwardHTLC", nil)
+}
+
+// ModuleCdc is the codec used for amino-JSON (de)serialization of queries,
+// genesis and IBC packet data. Sign-bytes for messages still go through the
+// app-wide codec passed into the module, per this repo's existing convention.
+var ModuleCdc = codec.New()
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+}
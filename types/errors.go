@@ -0,0 +1,33 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// ErrInvalidHashLock is returned when an HTLC's hash lock has the wrong
+// length for its hash algorithm
+var ErrInvalidHashLock = sdkerrors.Register(ModuleName, 1, "invalid hash lock")
+
+// ErrHTLCNotOpen is returned when claiming or refunding an HTLC that is not
+// in the Open state
+var ErrHTLCNotOpen = sdkerrors.Register(ModuleName, 2, "htlc not open")
+
+// ErrInvalidTimeLock is returned when an HTLC is created or forwarded with
+// a zero or otherwise invalid time lock
+var ErrInvalidTimeLock = sdkerrors.Register(ModuleName, 11, "invalid time lock")
+
+// ErrHTLCAlreadyExists is returned when creating an HTLC whose hash lock is
+// already in use by another outstanding HTLC
+var ErrHTLCAlreadyExists = sdkerrors.Register(ModuleName, 12, "htlc with this hash lock already exists")
+
+// ErrUnknownHTLC is returned when looking up an HTLC by a hash lock that
+// does not correspond to any known HTLC
+var ErrUnknownHTLC = sdkerrors.Register(ModuleName, 13, "unknown htlc")
+
+// ErrInvalidSecret is returned when a claim's secret does not hash to the
+// HTLC's hash lock under its configured hash algorithm
+var ErrInvalidSecret = sdkerrors.Register(ModuleName, 14, "secret does not match the hash lock")
+
+// ErrHTLCNotExpired is returned when refunding an HTLC before its expire
+// height has been reached
+var ErrHTLCNotExpired = sdkerrors.Register(ModuleName, 15, "htlc not yet expired")
@@ -8,22 +8,45 @@ import (
 
 // GenesisState contains all HTLC state that must be provided at genesis
 type GenesisState struct {
-	PendingHTLCs map[string]HTLC `json:"pending_htlcs" yaml:"pending_htlcs"` // claimable HTLCs
+	PendingHTLCs   map[string]HTLC `json:"pending_htlcs" yaml:"pending_htlcs"`         // claimable HTLCs
+	CompletedHTLCs []HTLC          `json:"completed_htlcs" yaml:"completed_htlcs"`     // claimed HTLCs, kept for history
+	RefundedHTLCs  []HTLC          `json:"refunded_htlcs" yaml:"refunded_htlcs"`       // expired and refunded HTLCs, kept for history
+	IBCHTLCs       []IBCHTLC       `json:"ibc_htlcs" yaml:"ibc_htlcs"`                 // outstanding IBC-linked HTLCs
+	RoutedHTLCs    []RoutedHTLC    `json:"routed_htlcs" yaml:"routed_htlcs"`           // outstanding multi-hop route definitions
+	RoutedHopHTLCs []RoutedHopHTLC `json:"routed_hop_htlcs" yaml:"routed_hop_htlcs"`   // outstanding per-hop locks of routed HTLCs
+	Params         Params          `json:"params" yaml:"params"`
 }
 
 // NewGenesisState constructs a new GenesisState instance
 func NewGenesisState(
 	pendingHTLCs map[string]HTLC,
+	completedHTLCs, refundedHTLCs []HTLC,
+	ibcHTLCs []IBCHTLC,
+	routedHTLCs []RoutedHTLC,
+	routedHopHTLCs []RoutedHopHTLC,
+	params Params,
 ) GenesisState {
 	return GenesisState{
-		PendingHTLCs: pendingHTLCs,
+		PendingHTLCs:   pendingHTLCs,
+		CompletedHTLCs: completedHTLCs,
+		RefundedHTLCs:  refundedHTLCs,
+		IBCHTLCs:       ibcHTLCs,
+		RoutedHTLCs:    routedHTLCs,
+		RoutedHopHTLCs: routedHopHTLCs,
+		Params:         params,
 	}
 }
 
 // DefaultGenesisState gets the raw genesis message for testing
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		PendingHTLCs: map[string]HTLC{},
+		PendingHTLCs:   map[string]HTLC{},
+		CompletedHTLCs: []HTLC{},
+		RefundedHTLCs:  []HTLC{},
+		IBCHTLCs:       []IBCHTLC{},
+		RoutedHTLCs:    []RoutedHTLC{},
+		RoutedHopHTLCs: []RoutedHopHTLC{},
+		Params:         DefaultParams(),
 	}
 }
 
@@ -36,8 +59,9 @@ func ValidateGenesis(data GenesisState) error {
 			return err
 		}
 
-		if len(hashLock) != HashLockLength {
-			return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes", HashLockLength)
+		expectedLength := HashLockLengthForAlgo(htlc.HashAlgo)
+		if len(hashLock) != expectedLength {
+			return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes for %s", expectedLength, htlc.HashAlgo)
 		}
 
 		if htlc.State != Open {
@@ -49,5 +73,73 @@ func ValidateGenesis(data GenesisState) error {
 		}
 	}
 
-	return nil
+	for _, htlc := range data.CompletedHTLCs {
+		if htlc.State != Completed {
+			return sdkerrors.Wrap(ErrHTLCNotOpen, "completed htlc must be in the completed state")
+		}
+		if err := htlc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, htlc := range data.RefundedHTLCs {
+		if htlc.State != Refunded {
+			return sdkerrors.Wrap(ErrHTLCNotOpen, "refunded htlc must be in the refunded state")
+		}
+		if err := htlc.Validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, ibcHTLC := range data.IBCHTLCs {
+		if len(ibcHTLC.HashLock) == 0 {
+			return sdkerrors.Wrap(ErrInvalidHashLock, "ibc htlc must reference a hash lock")
+		}
+
+		if ibcHTLC.PortID == "" || ibcHTLC.ChannelID == "" {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "ibc htlc must have a port id and a channel id")
+		}
+	}
+
+	for _, routed := range data.RoutedHTLCs {
+		if len(routed.Route) == 0 {
+			return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "routed htlc must have a non-empty route")
+		}
+		for i, hop := range routed.Route {
+			if err := hop.ValidateBasic(); err != nil {
+				return sdkerrors.Wrapf(err, "invalid hop %d", i)
+			}
+		}
+	}
+
+	for _, hopHTLC := range data.RoutedHopHTLCs {
+		if err := hopHTLC.HTLC.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return data.Params.Validate()
+}
+
+// MigrateHashAlgo backfills the HashAlgo field on genesis HTLCs exported
+// before hash algo selection was introduced. Every pre-existing HTLC was
+// created with a plain SHA256 hash lock, so validators upgrading from an
+// older version of this module can run this once on their exported genesis
+// to keep PendingHTLCs valid under the new per-algo length check.
+func MigrateHashAlgo(data GenesisState) GenesisState {
+	migrated := make(map[string]HTLC, len(data.PendingHTLCs))
+	for hashLockStr, htlc := range data.PendingHTLCs {
+		htlc.HashAlgo = SHA256
+		migrated[hashLockStr] = htlc
+	}
+
+	return GenesisState{
+		PendingHTLCs:   migrated,
+		CompletedHTLCs: data.CompletedHTLCs,
+		RefundedHTLCs:  data.RefundedHTLCs,
+		IBCHTLCs:       data.IBCHTLCs,
+		RoutedHTLCs:    data.RoutedHTLCs,
+		RoutedHopHTLCs: data.RoutedHopHTLCs,
+		Params:         data.Params,
+	}
 }
@@ -0,0 +1,60 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck
+	"golang.org/x/crypto/sha3"
+)
+
+func TestGetHashLockByAlgoBTCAndEVMAlgosIgnoreTimestamp(t *testing.T) {
+	secret := bytes.Repeat([]byte{0xAB}, SecretLength)
+
+	cases := []HashAlgo{SHA256D, RIPEMD160SHA256, KECCAK256}
+	for _, algo := range cases {
+		withoutTimestamp := GetHashLockByAlgo(secret, 0, algo)
+		withTimestamp := GetHashLockByAlgo(secret, 1234567890, algo)
+
+		if !bytes.Equal(withoutTimestamp, withTimestamp) {
+			t.Fatalf("%s: expected timestamp to be ignored so a secret revealed on a real BTC/ETH chain still validates, but hash lock changed", algo)
+		}
+	}
+}
+
+func TestGetHashLockByAlgoMatchesReferenceImplementations(t *testing.T) {
+	secret := bytes.Repeat([]byte{0xCD}, SecretLength)
+
+	first := sha256.Sum256(secret)
+
+	sha256dSum := sha256.Sum256(first[:])
+	if got := GetHashLockByAlgo(secret, 0, SHA256D); !bytes.Equal(got, sha256dSum[:]) {
+		t.Fatalf("SHA256D mismatch: got %x, want %x", got, sha256dSum)
+	}
+
+	ripemdHasher := ripemd160.New()
+	ripemdHasher.Write(first[:]) //nolint:errcheck
+	ripemdSum := ripemdHasher.Sum(nil)
+	if got := GetHashLockByAlgo(secret, 0, RIPEMD160SHA256); !bytes.Equal(got, ripemdSum) {
+		t.Fatalf("RIPEMD160SHA256 mismatch: got %x, want %x", got, ripemdSum)
+	}
+
+	keccakHasher := sha3.NewLegacyKeccak256()
+	keccakHasher.Write(secret) //nolint:errcheck
+	keccakSum := keccakHasher.Sum(nil)
+	if got := GetHashLockByAlgo(secret, 0, KECCAK256); !bytes.Equal(got, keccakSum) {
+		t.Fatalf("KECCAK256 mismatch: got %x, want %x", got, keccakSum)
+	}
+}
+
+func TestGetHashLockByAlgoSHA256StillMixesTimestamp(t *testing.T) {
+	secret := bytes.Repeat([]byte{0xEF}, SecretLength)
+
+	withoutTimestamp := GetHashLockByAlgo(secret, 0, SHA256)
+	withTimestamp := GetHashLockByAlgo(secret, 42, SHA256)
+
+	if bytes.Equal(withoutTimestamp, withTimestamp) {
+		t.Fatalf("expected SHA256 to still mix in the timestamp, keeping this module's original hash lock format")
+	}
+}
@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// HTLCState is the state of an HTLC over its lifetime
+type HTLCState byte
+
+const (
+	// Open means the HTLC has been created and is awaiting claim or refund
+	Open HTLCState = iota
+	// Completed means the HTLC has been claimed with the correct secret
+	Completed
+	// Refunded means the HTLC expired and the locked coins were returned
+	Refunded
+)
+
+// String implements the Stringer interface
+func (state HTLCState) String() string {
+	switch state {
+	case Open:
+		return "open"
+	case Completed:
+		return "completed"
+	case Refunded:
+		return "refunded"
+	default:
+		return "unknown"
+	}
+}
+
+// HTLC represents a single hash timelock contract
+type HTLC struct {
+	Sender               sdk.AccAddress `json:"sender" yaml:"sender"`
+	To                   sdk.AccAddress `json:"to" yaml:"to"`
+	ReceiverOnOtherChain string         `json:"receiver_on_other_chain" yaml:"receiver_on_other_chain"`
+	Amount               sdk.Coins      `json:"amount" yaml:"amount"`
+	HashLock             []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HashAlgo             HashAlgo       `json:"hash_algo" yaml:"hash_algo"`
+	Timestamp            uint64         `json:"timestamp" yaml:"timestamp"`
+	ExpireHeight         uint64         `json:"expire_height" yaml:"expire_height"`
+	State                HTLCState      `json:"state" yaml:"state"`
+}
+
+// NewHTLC constructs a new HTLC using the default SHA256 hash algo, for
+// backward compatibility with callers that predate hash algo selection.
+// expireHeight is an absolute block height, as documented on NewHTLCWithAlgo.
+func NewHTLC(
+	sender, to sdk.AccAddress,
+	receiverOnOtherChain string,
+	amount sdk.Coins,
+	hashLock []byte,
+	timestamp, expireHeight uint64,
+	state HTLCState,
+) HTLC {
+	return NewHTLCWithAlgo(sender, to, receiverOnOtherChain, amount, hashLock, SHA256, timestamp, expireHeight, state)
+}
+
+// NewHTLCWithAlgo constructs a new HTLC, recording the hash algo used to
+// derive its hash lock so the claim path can dispatch to the right hasher.
+// expireHeight is an absolute block height, not a duration: callers that
+// start from a relative time lock must add the current block height
+// themselves before calling this constructor.
+func NewHTLCWithAlgo(
+	sender, to sdk.AccAddress,
+	receiverOnOtherChain string,
+	amount sdk.Coins,
+	hashLock []byte,
+	hashAlgo HashAlgo,
+	timestamp, expireHeight uint64,
+	state HTLCState,
+) HTLC {
+	return HTLC{
+		Sender:               sender,
+		To:                   to,
+		ReceiverOnOtherChain: receiverOnOtherChain,
+		Amount:               amount,
+		HashLock:             hashLock,
+		HashAlgo:             hashAlgo,
+		Timestamp:            timestamp,
+		ExpireHeight:         expireHeight,
+		State:                state,
+	}
+}
+
+// Validate performs stateless validation of the HTLC
+func (h HTLC) Validate() error {
+	if h.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if h.To.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing recipient address")
+	}
+
+	if !h.Amount.IsValid() || !h.Amount.IsAllPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid amount: %s", h.Amount)
+	}
+
+	if len(h.HashLock) != HashLockLengthForAlgo(h.HashAlgo) {
+		return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes for %s", HashLockLengthForAlgo(h.HashAlgo), h.HashAlgo)
+	}
+
+	if h.ExpireHeight == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimeLock, "time lock can not be zero")
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface
+func (h HTLC) String() string {
+	return fmt.Sprintf(`HTLC:
+  Sender:                 %s
+  To:                     %s
+  ReceiverOnOtherChain:   %s
+  Amount:                 %s
+  HashLock:               %x
+  HashAlgo:               %s
+  Timestamp:              %d
+  ExpireHeight:           %d
+  State:                  %s`,
+		h.Sender, h.To, h.ReceiverOnOtherChain, h.Amount,
+		h.HashLock, h.HashAlgo, h.Timestamp, h.ExpireHeight, h.State,
+	)
+}
@@ -0,0 +1,153 @@
+package types
+
+import (
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	// PortID is the default port id that the htlc module binds to
+	PortID = "htlc"
+
+	// Version is the version of the htlc IBC packet protocol understood by
+	// this module. Channel handshakes with any other version are rejected.
+	Version = "ics20-htlc-1"
+)
+
+// HTLCPacketData is the IBC packet payload used to open a mirrored HTLC on a
+// counterparty chain, keeping the same hash lock as the HTLC on this chain so
+// that revealing the preimage on either side is enough to claim both legs.
+// Sender/To describe the local leg on the originating chain and are carried
+// only for reference; CounterpartyFunder is the account on the destination
+// chain that actually escrows the mirrored leg's funds, since an atomic swap
+// must be funded by the counterparty there, not by the originating sender
+// again.
+type HTLCPacketData struct {
+	Sender               sdk.AccAddress `json:"sender" yaml:"sender"`
+	To                   sdk.AccAddress `json:"to" yaml:"to"`
+	ReceiverOnOtherChain string         `json:"receiver_on_other_chain" yaml:"receiver_on_other_chain"`
+	CounterpartyFunder   sdk.AccAddress `json:"counterparty_funder" yaml:"counterparty_funder"`
+	Amount               sdk.Coins      `json:"amount" yaml:"amount"`
+	HashLock             []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HashAlgo             HashAlgo       `json:"hash_algo" yaml:"hash_algo"`
+	Timestamp            uint64         `json:"timestamp" yaml:"timestamp"`
+	TimeLock             uint64         `json:"time_lock" yaml:"time_lock"`
+}
+
+// NewHTLCPacketData constructs a new HTLCPacketData instance
+func NewHTLCPacketData(
+	sender, to sdk.AccAddress,
+	receiverOnOtherChain string,
+	counterpartyFunder sdk.AccAddress,
+	amount sdk.Coins,
+	hashLock []byte,
+	hashAlgo HashAlgo,
+	timestamp, timeLock uint64,
+) HTLCPacketData {
+	return HTLCPacketData{
+		Sender:               sender,
+		To:                   to,
+		ReceiverOnOtherChain: receiverOnOtherChain,
+		CounterpartyFunder:   counterpartyFunder,
+		Amount:               amount,
+		HashLock:             hashLock,
+		HashAlgo:             hashAlgo,
+		Timestamp:            timestamp,
+		TimeLock:             timeLock,
+	}
+}
+
+// ValidateBasic performs stateless validation of the packet data, mirroring
+// the checks MsgCreateHTLC applies to the local leg of the swap
+func (p HTLCPacketData) ValidateBasic() error {
+	if p.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if p.To.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing recipient address")
+	}
+
+	if p.CounterpartyFunder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing counterparty funder address")
+	}
+
+	if !p.Amount.IsValid() || !p.Amount.IsAllPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid amount: %s", p.Amount)
+	}
+
+	expectedLength := HashLockLengthForAlgo(p.HashAlgo)
+	if len(p.HashLock) != expectedLength {
+		return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes for %s", expectedLength, p.HashAlgo)
+	}
+
+	if p.TimeLock == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimeLock, "time lock can not be zero")
+	}
+
+	return nil
+}
+
+// GetBytes returns the canonical JSON encoding of the packet data, suitable
+// for hashing or including in an IBC packet commitment
+func (p HTLCPacketData) GetBytes() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// PacketClaimHTLC is the acknowledgement relayed back to the sending chain
+// once the mirrored HTLC has been claimed. It carries the preimage that
+// unlocked the counterpart HTLC so the origin HTLC can be claimed with the
+// same secret, completing the atomic swap on both chains.
+type PacketClaimHTLC struct {
+	HashLock []byte `json:"hash_lock" yaml:"hash_lock"`
+	Secret   []byte `json:"secret" yaml:"secret"`
+}
+
+// NewPacketClaimHTLC constructs a new PacketClaimHTLC acknowledgement
+func NewPacketClaimHTLC(hashLock, secret []byte) PacketClaimHTLC {
+	return PacketClaimHTLC{
+		HashLock: hashLock,
+		Secret:   secret,
+	}
+}
+
+// GetBytes returns the canonical JSON encoding of the acknowledgement data
+func (p PacketClaimHTLC) GetBytes() []byte {
+	b, err := json.Marshal(p)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// IBCHTLC links a locally created HTLC to the IBC channel it was opened
+// over, so that a claim or a channel timeout can be routed back to the
+// correct counterparty packet. Initiator distinguishes which side of the
+// swap this link represents: true for the leg that sent the original
+// packet and is waiting on an acknowledgement or timeout, false for the
+// mirrored leg opened by OnRecvPacket, which must write a PacketClaimHTLC
+// acknowledgement back to the initiator when claimed.
+type IBCHTLC struct {
+	HashLock  []byte `json:"hash_lock" yaml:"hash_lock"`
+	PortID    string `json:"port_id" yaml:"port_id"`
+	ChannelID string `json:"channel_id" yaml:"channel_id"`
+	Sequence  uint64 `json:"sequence" yaml:"sequence"`
+	Initiator bool   `json:"initiator" yaml:"initiator"`
+}
+
+// NewIBCHTLC constructs a new IBCHTLC link
+func NewIBCHTLC(hashLock []byte, portID, channelID string, sequence uint64, initiator bool) IBCHTLC {
+	return IBCHTLC{
+		HashLock:  hashLock,
+		PortID:    portID,
+		ChannelID: channelID,
+		Sequence:  sequence,
+		Initiator: initiator,
+	}
+}
@@ -0,0 +1,37 @@
+package types
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func validHTLCPacketData(hashLock []byte, algo HashAlgo) HTLCPacketData {
+	return NewHTLCPacketData(
+		sdk.AccAddress("sender_____________"),
+		sdk.AccAddress("recipient__________"),
+		"",
+		sdk.AccAddress("counterparty_funder"),
+		sdk.NewCoins(sdk.NewInt64Coin("stake", 100)),
+		hashLock,
+		algo,
+		0,
+		100,
+	)
+}
+
+func TestHTLCPacketDataValidateBasicAcceptsPerAlgoLength(t *testing.T) {
+	// a 20-byte RIPEMD160(SHA256) lock, as used by BTC-family chains, must
+	// not be rejected for being shorter than the SHA256-family length
+	data := validHTLCPacketData(make([]byte, 20), RIPEMD160SHA256)
+	if err := data.ValidateBasic(); err != nil {
+		t.Fatalf("expected a 20-byte lock to be valid for RIPEMD160SHA256, got: %v", err)
+	}
+}
+
+func TestHTLCPacketDataValidateBasicRejectsWrongLength(t *testing.T) {
+	data := validHTLCPacketData(make([]byte, 20), SHA256)
+	if err := data.ValidateBasic(); err == nil {
+		t.Fatalf("expected a 20-byte lock to be rejected for SHA256")
+	}
+}
@@ -0,0 +1,15 @@
+package types
+
+const (
+	// ModuleName is the name of the htlc module
+	ModuleName = "htlc"
+
+	// StoreKey is the default store key for the htlc module
+	StoreKey = ModuleName
+
+	// RouterKey is the message route for the htlc module
+	RouterKey = ModuleName
+
+	// QuerierRoute is the querier route for the htlc module
+	QuerierRoute = ModuleName
+)
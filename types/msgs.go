@@ -0,0 +1,192 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// SecretLength is the required length, in bytes, of an HTLC secret
+const SecretLength = 32
+
+// HashLockLength is the required length, in bytes, of a SHA256-family hash
+// lock. RIPEMD160-based hash locks use a different length; see
+// HashLockLengthForAlgo.
+const HashLockLength = 32
+
+const (
+	TypeMsgCreateHTLC = "create_htlc"
+	TypeMsgClaimHTLC  = "claim_htlc"
+	TypeMsgRefundHTLC = "refund_htlc"
+)
+
+// MsgCreateHTLC locks coins from Sender to To, claimable by revealing the
+// preimage of HashLock before TimeLock expires
+type MsgCreateHTLC struct {
+	Sender               sdk.AccAddress `json:"sender" yaml:"sender"`
+	To                   sdk.AccAddress `json:"to" yaml:"to"`
+	ReceiverOnOtherChain string         `json:"receiver_on_other_chain" yaml:"receiver_on_other_chain"`
+	Amount               sdk.Coins      `json:"amount" yaml:"amount"`
+	HashLock             []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HashAlgo             HashAlgo       `json:"hash_algo" yaml:"hash_algo"`
+	Timestamp            uint64         `json:"timestamp" yaml:"timestamp"`
+	TimeLock             uint64         `json:"time_lock" yaml:"time_lock"`
+	// DestChain is the channel id to route the swap through IBC, opening a
+	// mirrored HTLC on the chain connected to it. Empty for local-only HTLCs.
+	DestChain string `json:"dest_chain,omitempty" yaml:"dest_chain,omitempty"`
+}
+
+// NewMsgCreateHTLC constructs a new MsgCreateHTLC using the default SHA256
+// hash algo, for backward compatibility with callers that predate hash
+// algo selection
+func NewMsgCreateHTLC(
+	sender, to sdk.AccAddress,
+	receiverOnOtherChain string,
+	amount sdk.Coins,
+	hashLock []byte,
+	timestamp, timeLock uint64,
+) MsgCreateHTLC {
+	return MsgCreateHTLC{
+		Sender:               sender,
+		To:                   to,
+		ReceiverOnOtherChain: receiverOnOtherChain,
+		Amount:               amount,
+		HashLock:             hashLock,
+		HashAlgo:             SHA256,
+		Timestamp:            timestamp,
+		TimeLock:             timeLock,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgCreateHTLC) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgCreateHTLC) Type() string { return TypeMsgCreateHTLC }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreateHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if msg.To.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing recipient address")
+	}
+
+	if !msg.Amount.IsValid() || !msg.Amount.IsAllPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid amount: %s", msg.Amount)
+	}
+
+	if len(msg.HashLock) != HashLockLengthForAlgo(msg.HashAlgo) {
+		return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes for %s", HashLockLengthForAlgo(msg.HashAlgo), msg.HashAlgo)
+	}
+
+	if msg.TimeLock == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimeLock, "time lock can not be zero")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreateHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgCreateHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgClaimHTLC claims the coins locked in an HTLC by revealing its secret
+type MsgClaimHTLC struct {
+	Sender   sdk.AccAddress `json:"sender" yaml:"sender"`
+	HashLock []byte         `json:"hash_lock" yaml:"hash_lock"`
+	Secret   []byte         `json:"secret" yaml:"secret"`
+}
+
+// NewMsgClaimHTLC constructs a new MsgClaimHTLC
+func NewMsgClaimHTLC(sender sdk.AccAddress, hashLock, secret []byte) MsgClaimHTLC {
+	return MsgClaimHTLC{
+		Sender:   sender,
+		HashLock: hashLock,
+		Secret:   secret,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgClaimHTLC) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgClaimHTLC) Type() string { return TypeMsgClaimHTLC }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgClaimHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if len(msg.HashLock) == 0 {
+		return sdkerrors.Wrap(ErrInvalidHashLock, "hash lock can not be empty")
+	}
+
+	if len(msg.Secret) == 0 {
+		return sdkerrors.Wrap(ErrInvalidSecret, "secret can not be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgClaimHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgClaimHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgRefundHTLC refunds the coins locked in an expired HTLC back to its
+// sender
+type MsgRefundHTLC struct {
+	Sender   sdk.AccAddress `json:"sender" yaml:"sender"`
+	HashLock []byte         `json:"hash_lock" yaml:"hash_lock"`
+}
+
+// NewMsgRefundHTLC constructs a new MsgRefundHTLC
+func NewMsgRefundHTLC(sender sdk.AccAddress, hashLock []byte) MsgRefundHTLC {
+	return MsgRefundHTLC{
+		Sender:   sender,
+		HashLock: hashLock,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgRefundHTLC) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgRefundHTLC) Type() string { return TypeMsgRefundHTLC }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgRefundHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if len(msg.HashLock) == 0 {
+		return sdkerrors.Wrap(ErrInvalidHashLock, "hash lock can not be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgRefundHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgRefundHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
@@ -0,0 +1,133 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+const (
+	TypeMsgCreateRoutedHTLC = "create_routed_htlc"
+	TypeMsgForwardHTLC      = "forward_htlc"
+)
+
+// MsgCreateRoutedHTLC opens a chain of HTLCs along Route, all sharing the
+// same HashLock, letting this module act as a Lightning-style routing
+// layer across accounts (or IBC-connected chains). Only the first hop is
+// locked immediately; each subsequent hop is opened by its forwarder via
+// MsgForwardHTLC once it has observed the previous hop.
+type MsgCreateRoutedHTLC struct {
+	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
+	Route     []Hop          `json:"route" yaml:"route"`
+	HashLock  []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HashAlgo  HashAlgo       `json:"hash_algo" yaml:"hash_algo"`
+	Timestamp uint64         `json:"timestamp" yaml:"timestamp"`
+	TimeLock  uint64         `json:"time_lock" yaml:"time_lock"`
+}
+
+// NewMsgCreateRoutedHTLC constructs a new MsgCreateRoutedHTLC
+func NewMsgCreateRoutedHTLC(
+	sender sdk.AccAddress,
+	route []Hop,
+	hashLock []byte,
+	hashAlgo HashAlgo,
+	timestamp, timeLock uint64,
+) MsgCreateRoutedHTLC {
+	return MsgCreateRoutedHTLC{
+		Sender:    sender,
+		Route:     route,
+		HashLock:  hashLock,
+		HashAlgo:  hashAlgo,
+		Timestamp: timestamp,
+		TimeLock:  timeLock,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgCreateRoutedHTLC) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgCreateRoutedHTLC) Type() string { return TypeMsgCreateRoutedHTLC }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgCreateRoutedHTLC) ValidateBasic() error {
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing sender address")
+	}
+
+	if len(msg.Route) == 0 {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "route can not be empty")
+	}
+
+	for i, hop := range msg.Route {
+		if err := hop.ValidateBasic(); err != nil {
+			return sdkerrors.Wrapf(err, "invalid hop %d", i)
+		}
+	}
+
+	if len(msg.HashLock) != HashLockLengthForAlgo(msg.HashAlgo) {
+		return sdkerrors.Wrapf(ErrInvalidHashLock, "length of the hash lock must be %d in bytes for %s", HashLockLengthForAlgo(msg.HashAlgo), msg.HashAlgo)
+	}
+
+	if msg.TimeLock == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimeLock, "time lock can not be zero")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgCreateRoutedHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgCreateRoutedHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgForwardHTLC is submitted by an intermediate forwarder to open the next
+// hop of a routed HTLC it is currently holding, decrementing the timelock
+// and amount according to the route's declared hop parameters
+type MsgForwardHTLC struct {
+	Forwarder sdk.AccAddress `json:"forwarder" yaml:"forwarder"`
+	HashLock  []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HopIndex  uint32         `json:"hop_index" yaml:"hop_index"`
+}
+
+// NewMsgForwardHTLC constructs a new MsgForwardHTLC
+func NewMsgForwardHTLC(forwarder sdk.AccAddress, hashLock []byte, hopIndex uint32) MsgForwardHTLC {
+	return MsgForwardHTLC{
+		Forwarder: forwarder,
+		HashLock:  hashLock,
+		HopIndex:  hopIndex,
+	}
+}
+
+// Route implements sdk.Msg
+func (msg MsgForwardHTLC) Route() string { return RouterKey }
+
+// Type implements sdk.Msg
+func (msg MsgForwardHTLC) Type() string { return TypeMsgForwardHTLC }
+
+// ValidateBasic implements sdk.Msg
+func (msg MsgForwardHTLC) ValidateBasic() error {
+	if msg.Forwarder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing forwarder address")
+	}
+
+	if len(msg.HashLock) == 0 {
+		return sdkerrors.Wrap(ErrInvalidHashLock, "hash lock can not be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes implements sdk.Msg
+func (msg MsgForwardHTLC) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners implements sdk.Msg
+func (msg MsgForwardHTLC) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Forwarder}
+}
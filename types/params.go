@@ -0,0 +1,31 @@
+package types
+
+import (
+	paramtypes "github.com/cosmos/cosmos-sdk/x/params/types"
+)
+
+// ParamKeyTable returns the param key table for the htlc module
+func ParamKeyTable() paramtypes.KeyTable {
+	return paramtypes.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// Params defines the parameters of the htlc module. It is currently empty,
+// but exists so the module's params query endpoint and genesis have a
+// stable place to grow into as configurable behavior (e.g. per-algo fees,
+// max time lock) is added.
+type Params struct{}
+
+// DefaultParams returns the default htlc module parameters
+func DefaultParams() Params {
+	return Params{}
+}
+
+// ParamSetPairs implements the paramtypes.ParamSet interface
+func (p *Params) ParamSetPairs() paramtypes.ParamSetPairs {
+	return paramtypes.ParamSetPairs{}
+}
+
+// Validate performs basic validation of the module parameters
+func (p Params) Validate() error {
+	return nil
+}
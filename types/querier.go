@@ -0,0 +1,54 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Querier path constants
+const (
+	QueryHTLC   = "htlc"
+	QueryHTLCs  = "htlcs"
+	QueryParams = "params"
+)
+
+// QueryHTLCParams is the parameter for the QueryHTLC query, looking up a
+// single HTLC by its hash lock
+type QueryHTLCParams struct {
+	HashLock []byte `json:"hash_lock" yaml:"hash_lock"`
+}
+
+// NewQueryHTLCParams constructs a new QueryHTLCParams
+func NewQueryHTLCParams(hashLock []byte) QueryHTLCParams {
+	return QueryHTLCParams{HashLock: hashLock}
+}
+
+// QueryHTLCsParams is the parameter for the QueryHTLCs query, filtering the
+// full HTLC set by state and/or sender/receiver, with pagination. State is a
+// pointer so that "no filter" can round-trip through JSON as a missing field
+// instead of relying on an unserialized side channel.
+type QueryHTLCsParams struct {
+	State    *HTLCState     `json:"state,omitempty" yaml:"state,omitempty"`
+	Sender   sdk.AccAddress `json:"sender" yaml:"sender"`
+	Receiver sdk.AccAddress `json:"receiver" yaml:"receiver"`
+	Page     int            `json:"page" yaml:"page"`
+	Limit    int            `json:"limit" yaml:"limit"`
+}
+
+// NewQueryHTLCsParams constructs a new QueryHTLCsParams
+func NewQueryHTLCsParams(state HTLCState, hasState bool, sender, receiver sdk.AccAddress, page, limit int) QueryHTLCsParams {
+	params := QueryHTLCsParams{
+		Sender:   sender,
+		Receiver: receiver,
+		Page:     page,
+		Limit:    limit,
+	}
+	if hasState {
+		params.State = &state
+	}
+	return params
+}
+
+// HasState reports whether the query filters by HTLC state
+func (p QueryHTLCsParams) HasState() bool {
+	return p.State != nil
+}
@@ -0,0 +1,45 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestQueryHTLCsParamsHasStateSurvivesJSON(t *testing.T) {
+	params := NewQueryHTLCsParams(Open, true, nil, nil, 1, 100)
+
+	bz, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded QueryHTLCsParams
+	if err := json.Unmarshal(bz, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if !decoded.HasState() {
+		t.Fatalf("expected HasState() to be true after a JSON round-trip, got false")
+	}
+	if decoded.State == nil || *decoded.State != Open {
+		t.Fatalf("expected decoded state to be Open, got %v", decoded.State)
+	}
+}
+
+func TestQueryHTLCsParamsNoStateSurvivesJSON(t *testing.T) {
+	params := NewQueryHTLCsParams(Open, false, nil, nil, 1, 100)
+
+	bz, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var decoded QueryHTLCsParams
+	if err := json.Unmarshal(bz, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if decoded.HasState() {
+		t.Fatalf("expected HasState() to be false when no state filter was set, got true")
+	}
+}
@@ -0,0 +1,111 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// Hop is one leg of a routed HTLC, naming the forwarder that must open the
+// next-hop HTLC and the timelock/fee it is allowed to deduct from the
+// amount it forwards
+type Hop struct {
+	Forwarder     sdk.AccAddress `json:"forwarder" yaml:"forwarder"`
+	Amount        sdk.Coins      `json:"amount" yaml:"amount"`
+	Fee           sdk.Coins      `json:"fee" yaml:"fee"`
+	TimeLockDelta uint64         `json:"time_lock_delta" yaml:"time_lock_delta"`
+}
+
+// NewHop constructs a new Hop
+func NewHop(forwarder sdk.AccAddress, amount, fee sdk.Coins, timeLockDelta uint64) Hop {
+	return Hop{
+		Forwarder:     forwarder,
+		Amount:        amount,
+		Fee:           fee,
+		TimeLockDelta: timeLockDelta,
+	}
+}
+
+// ValidateBasic performs stateless validation of a single hop
+func (h Hop) ValidateBasic() error {
+	if h.Forwarder.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "missing forwarder address")
+	}
+
+	if !h.Amount.IsValid() || !h.Amount.IsAllPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid hop amount: %s", h.Amount)
+	}
+
+	if !h.Fee.IsValid() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid hop fee: %s", h.Fee)
+	}
+
+	if h.TimeLockDelta == 0 {
+		return sdkerrors.Wrap(ErrInvalidTimeLock, "hop time lock delta can not be zero")
+	}
+
+	return nil
+}
+
+// ValidateHop checks that forwarding from an incoming leg to an outgoing
+// leg respects the routing invariants: the outgoing timelock must be
+// strictly less than the incoming one, and the outgoing amount can be at
+// most the incoming amount minus the hop's declared fee.
+func ValidateHop(incomingAmount sdk.Coins, incomingTimeLock uint64, hop Hop, outgoingTimeLock uint64) error {
+	if outgoingTimeLock >= incomingTimeLock {
+		return sdkerrors.Wrapf(ErrInvalidTimeLock, "outgoing time lock %d must be strictly less than incoming time lock %d", outgoingTimeLock, incomingTimeLock)
+	}
+
+	if incomingTimeLock-outgoingTimeLock < hop.TimeLockDelta {
+		return sdkerrors.Wrapf(ErrInvalidTimeLock, "time lock must decrease by at least %d", hop.TimeLockDelta)
+	}
+
+	maxOutgoing, isNegative := incomingAmount.SafeSub(hop.Fee)
+	if isNegative {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "hop fee %s exceeds incoming amount %s", hop.Fee, incomingAmount)
+	}
+
+	if !hop.Amount.IsAllLTE(maxOutgoing) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "outgoing amount %s exceeds incoming amount %s minus fee %s", hop.Amount, incomingAmount, hop.Fee)
+	}
+
+	return nil
+}
+
+// RoutedHTLC is the immutable route definition of a multi-hop HTLC, keyed
+// by its shared hash lock. The per-hop locks that this route opens as it
+// is forwarded are stored separately, keyed by (hash lock, hop index).
+type RoutedHTLC struct {
+	Sender    sdk.AccAddress `json:"sender" yaml:"sender"`
+	Route     []Hop          `json:"route" yaml:"route"`
+	HashLock  []byte         `json:"hash_lock" yaml:"hash_lock"`
+	HashAlgo  HashAlgo       `json:"hash_algo" yaml:"hash_algo"`
+	Timestamp uint64         `json:"timestamp" yaml:"timestamp"`
+}
+
+// NewRoutedHTLC constructs a new RoutedHTLC
+func NewRoutedHTLC(sender sdk.AccAddress, route []Hop, hashLock []byte, hashAlgo HashAlgo, timestamp uint64) RoutedHTLC {
+	return RoutedHTLC{
+		Sender:    sender,
+		Route:     route,
+		HashLock:  hashLock,
+		HashAlgo:  hashAlgo,
+		Timestamp: timestamp,
+	}
+}
+
+// RoutedHopHTLC is a single opened leg of a routed HTLC, linking the shared
+// hash lock and hop index back to the HTLC locking that leg's funds
+type RoutedHopHTLC struct {
+	HashLock []byte `json:"hash_lock" yaml:"hash_lock"`
+	HopIndex uint32 `json:"hop_index" yaml:"hop_index"`
+	HTLC     HTLC   `json:"htlc" yaml:"htlc"`
+}
+
+// NewRoutedHopHTLC constructs a new RoutedHopHTLC
+func NewRoutedHopHTLC(hashLock []byte, hopIndex uint32, htlc HTLC) RoutedHopHTLC {
+	return RoutedHopHTLC{
+		HashLock: hashLock,
+		HopIndex: hopIndex,
+		HTLC:     htlc,
+	}
+}